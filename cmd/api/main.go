@@ -21,7 +21,12 @@ func main() {
 	fmt.Printf("Starting TruffleHog API server on %s\n", *addr)
 	fmt.Println("Endpoints:")
 	fmt.Println("  POST   /api/v1/scan         - Initiate a new scan")
+	fmt.Println("  DELETE /api/v1/scan         - Cancel an in-flight scan")
+	fmt.Println("  POST   /api/v1/scan/cancel  - Cancel an in-flight scan")
 	fmt.Println("  GET    /api/v1/scan/status  - Get scan status")
+	fmt.Println("  GET    /api/v1/scan/webhooks - Get webhook delivery history")
+	fmt.Println("  GET    /api/v1/scan/stream  - Stream scan results (SSE)")
+	fmt.Println("  GET    /api/v1/scan/ws      - Stream scan results (WebSocket)")
 	fmt.Println("  GET    /api/v1/scans        - List all scans")
 	fmt.Println("  GET    /health              - Health check")
 
@@ -0,0 +1,266 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/time/rate"
+)
+
+// Principal identifies the authenticated caller of a request. Every
+// request that reaches a handler has exactly one, attached to its context
+// by authenticator.middleware.
+type Principal struct {
+	TenantID string
+	Method   string // "api_key", "hmac", or "oidc"
+}
+
+type principalContextKey struct{}
+
+func principalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalContextKey{}).(Principal)
+	return p, ok
+}
+
+// hmacMaxClockSkew bounds how far a THOG-HMAC request's ts= may drift from
+// the server's clock before it's rejected as a replay risk.
+const hmacMaxClockSkew = 5 * time.Minute
+
+// AuthConfig configures the three authentication methods
+// authenticator.authenticate accepts. All three may be configured at once; a request is authenticated
+// by whichever scheme its Authorization header indicates.
+type AuthConfig struct {
+	// APIKeys maps a static API key to the tenant it authenticates as.
+	APIKeys map[string]string
+
+	// HMACSecrets maps a key ID (the "key=" field in the Authorization
+	// header) to its shared secret and owning tenant.
+	HMACSecrets map[string]HMACCredential
+
+	// OIDCIssuer and OIDCAudience configure bearer-token verification
+	// against the issuer's published JWKS. Leave OIDCIssuer empty to
+	// disable OIDC auth.
+	OIDCIssuer   string
+	OIDCAudience string
+
+	// RateLimitRPS and RateLimitBurst configure the per-principal token
+	// bucket. Zero disables rate limiting.
+	RateLimitRPS   float64
+	RateLimitBurst int
+}
+
+// HMACCredential is the secret and tenant bound to one HMAC key ID.
+type HMACCredential struct {
+	Secret   string
+	TenantID string
+}
+
+// defaultTenantID is the Principal assigned to every request when no
+// authentication method is configured at all, so an operator who hasn't set
+// up API keys/HMAC/OIDC yet (e.g. local development) keeps the server's
+// prior unauthenticated behavior instead of locking themselves out.
+const defaultTenantID = "default"
+
+// authenticator resolves an http.Request to a Principal using whichever of
+// static API keys, HMAC-signed requests, or OIDC bearer tokens the request
+// presents.
+type authenticator struct {
+	config       AuthConfig
+	enabled      bool
+	oidcVerifier *oidc.IDTokenVerifier
+	limiters     map[string]*rate.Limiter
+	limitersMu   sync.Mutex
+}
+
+// newAuthenticator builds an authenticator from config. If config.OIDCIssuer
+// is set, it fetches that issuer's OIDC discovery document (and therefore
+// makes a network call) before returning.
+func newAuthenticator(ctx context.Context, config AuthConfig) (*authenticator, error) {
+	a := &authenticator{
+		config:   config,
+		enabled:  len(config.APIKeys) > 0 || len(config.HMACSecrets) > 0 || config.OIDCIssuer != "",
+		limiters: make(map[string]*rate.Limiter),
+	}
+
+	if config.OIDCIssuer != "" {
+		provider, err := oidc.NewProvider(ctx, config.OIDCIssuer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize OIDC provider %s: %w", config.OIDCIssuer, err)
+		}
+		a.oidcVerifier = provider.Verifier(&oidc.Config{ClientID: config.OIDCAudience})
+	}
+
+	return a, nil
+}
+
+// middleware wraps next with authentication and, if configured, per-tenant
+// rate limiting. On failure it writes the response itself and never calls
+// next.
+func (a *authenticator) middleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal := Principal{TenantID: defaultTenantID}
+		if a.enabled {
+			p, err := a.authenticate(r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			principal = p
+		}
+
+		if a.config.RateLimitRPS > 0 {
+			if !a.limiterFor(principal.TenantID).Allow() {
+				w.Header().Set("Retry-After", "1")
+				http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+				return
+			}
+		}
+
+		ctx := context.WithValue(r.Context(), principalContextKey{}, principal)
+		next(w, r.WithContext(ctx))
+	}
+}
+
+func (a *authenticator) limiterFor(tenantID string) *rate.Limiter {
+	a.limitersMu.Lock()
+	defer a.limitersMu.Unlock()
+
+	limiter, ok := a.limiters[tenantID]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(a.config.RateLimitRPS), a.config.RateLimitBurst)
+		a.limiters[tenantID] = limiter
+	}
+	return limiter
+}
+
+func (a *authenticator) authenticate(r *http.Request) (Principal, error) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return Principal{}, fmt.Errorf("missing Authorization header")
+	}
+
+	switch {
+	case strings.HasPrefix(authHeader, "ApiKey "):
+		return a.authenticateAPIKey(strings.TrimPrefix(authHeader, "ApiKey "))
+	case strings.HasPrefix(authHeader, "THOG-HMAC "):
+		return a.authenticateHMAC(r, strings.TrimPrefix(authHeader, "THOG-HMAC "))
+	case strings.HasPrefix(authHeader, "Bearer "):
+		return a.authenticateOIDC(r.Context(), strings.TrimPrefix(authHeader, "Bearer "))
+	default:
+		return Principal{}, fmt.Errorf("unrecognized Authorization scheme")
+	}
+}
+
+func (a *authenticator) authenticateAPIKey(key string) (Principal, error) {
+	tenantID, ok := a.config.APIKeys[key]
+	if !ok {
+		return Principal{}, fmt.Errorf("invalid API key")
+	}
+	return Principal{TenantID: tenantID, Method: "api_key"}, nil
+}
+
+// authenticateHMAC validates a "THOG-HMAC key=…, sig=…, ts=…" header: the
+// timestamp must be within hmacMaxClockSkew of now, and sig must equal
+// HMAC-SHA256(secret, method+"\n"+path+"\n"+ts+"\n"+body).
+func (a *authenticator) authenticateHMAC(r *http.Request, params string) (Principal, error) {
+	fields := parseHMACParams(params)
+	keyID, sig, ts := fields["key"], fields["sig"], fields["ts"]
+	if keyID == "" || sig == "" || ts == "" {
+		return Principal{}, fmt.Errorf("malformed THOG-HMAC header")
+	}
+
+	cred, ok := a.config.HMACSecrets[keyID]
+	if !ok {
+		return Principal{}, fmt.Errorf("unknown HMAC key")
+	}
+
+	tsSeconds, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return Principal{}, fmt.Errorf("invalid ts")
+	}
+	requestTime := time.Unix(tsSeconds, 0)
+	if skew := time.Since(requestTime); skew < -hmacMaxClockSkew || skew > hmacMaxClockSkew {
+		return Principal{}, fmt.Errorf("request timestamp outside allowed clock skew")
+	}
+
+	body, err := readAndRestoreBody(r)
+	if err != nil {
+		return Principal{}, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(cred.Secret))
+	fmt.Fprintf(mac, "%s\n%s\n%s\n", r.Method, r.URL.Path, ts)
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(sig)) {
+		return Principal{}, fmt.Errorf("invalid HMAC signature")
+	}
+
+	return Principal{TenantID: cred.TenantID, Method: "hmac"}, nil
+}
+
+func (a *authenticator) authenticateOIDC(ctx context.Context, rawToken string) (Principal, error) {
+	if a.oidcVerifier == nil {
+		return Principal{}, fmt.Errorf("OIDC authentication is not configured")
+	}
+
+	idToken, err := a.oidcVerifier.Verify(ctx, rawToken)
+	if err != nil {
+		return Principal{}, fmt.Errorf("invalid OIDC token: %w", err)
+	}
+
+	var claims struct {
+		TenantID string `json:"tenant_id"`
+		Subject  string `json:"sub"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return Principal{}, fmt.Errorf("failed to parse OIDC claims: %w", err)
+	}
+
+	tenantID := claims.TenantID
+	if tenantID == "" {
+		tenantID = claims.Subject
+	}
+
+	return Principal{TenantID: tenantID, Method: "oidc"}, nil
+}
+
+// parseHMACParams parses the comma-separated "key=value" pairs from a
+// THOG-HMAC Authorization header value.
+func parseHMACParams(params string) map[string]string {
+	fields := make(map[string]string)
+	for _, part := range strings.Split(params, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) == 2 {
+			fields[kv[0]] = kv[1]
+		}
+	}
+	return fields
+}
+
+// readAndRestoreBody reads r.Body fully and replaces it with a fresh reader
+// over the same bytes, so the handler after authentication can still decode
+// it as JSON.
+func readAndRestoreBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,28 @@
+package api
+
+// detectorFilter restricts results to a configured allow-list of detector
+// type names. An empty filter allows everything, matching the zero-value
+// behavior of ScanRequest.IncludeOnly being unset.
+type detectorFilter struct {
+	allowed map[string]struct{}
+}
+
+func newDetectorFilter(includeOnly []string) detectorFilter {
+	if len(includeOnly) == 0 {
+		return detectorFilter{}
+	}
+
+	allowed := make(map[string]struct{}, len(includeOnly))
+	for _, name := range includeOnly {
+		allowed[name] = struct{}{}
+	}
+	return detectorFilter{allowed: allowed}
+}
+
+func (f detectorFilter) allows(detectorType string) bool {
+	if len(f.allowed) == 0 {
+		return true
+	}
+	_, ok := f.allowed[detectorType]
+	return ok
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"sync"
@@ -12,14 +13,24 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
-	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/git"
 )
 
 type ScanRequest struct {
-	RepoURL     string   `json:"repo_url"`
-	WebhookURL  string   `json:"webhook_url"`
-	Verify      bool     `json:"verify"`
+	// RepoURL, Verify, and IncludeOnly are retained for backward
+	// compatibility: a request that omits SourceType is treated as a "git"
+	// scan, built from these fields when SourceConfig is also omitted.
+	// IncludeOnly, when set, also filters detectors for any source type.
+	// New clients should prefer SourceType
+	// and SourceConfig, which support non-git sources too.
+	RepoURL     string   `json:"repo_url,omitempty"`
+	Verify      bool     `json:"verify,omitempty"`
 	IncludeOnly []string `json:"include_only,omitempty"`
+
+	SourceType   string          `json:"source_type,omitempty"`
+	SourceConfig json.RawMessage `json:"source_config,omitempty"`
+
+	WebhookURL     string `json:"webhook_url"`
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty"`
 }
 
 type ScanResponse struct {
@@ -30,16 +41,17 @@ type ScanResponse struct {
 }
 
 type ScanResult struct {
-	ScanID       string              `json:"scan_id"`
-	Status       string              `json:"status"`
-	RepoURL      string              `json:"repo_url"`
-	StartedAt    string              `json:"started_at"`
-	CompletedAt  string              `json:"completed_at,omitempty"`
-	TotalSecrets int                 `json:"total_secrets"`
-	Verified     int                 `json:"verified"`
-	Unverified   int                 `json:"unverified"`
-	Secrets      []SecretResult      `json:"secrets,omitempty"`
-	Error        string              `json:"error,omitempty"`
+	ScanID       string         `json:"scan_id"`
+	Status       string         `json:"status"`
+	RepoURL      string         `json:"repo_url"`
+	TenantID     string         `json:"tenant_id,omitempty"`
+	StartedAt    string         `json:"started_at"`
+	CompletedAt  string         `json:"completed_at,omitempty"`
+	TotalSecrets int            `json:"total_secrets"`
+	Verified     int            `json:"verified"`
+	Unverified   int            `json:"unverified"`
+	Secrets      []SecretResult `json:"secrets,omitempty"`
+	Error        string         `json:"error,omitempty"`
 }
 
 type SecretResult struct {
@@ -51,6 +63,9 @@ type SecretResult struct {
 	ExtraData    map[string]string `json:"extra_data,omitempty"`
 	SourceName   string            `json:"source_name"`
 	SourceType   string            `json:"source_type"`
+	File         string            `json:"file,omitempty"`
+	Commit       string            `json:"commit,omitempty"`
+	Line         int64             `json:"line,omitempty"`
 }
 
 type WebhookPayload struct {
@@ -59,23 +74,60 @@ type WebhookPayload struct {
 	Timestamp  string     `json:"timestamp"`
 }
 
+// defaultScanTimeout bounds scans that don't specify timeout_seconds, so a
+// stalled source or engine can't pin a goroutine (and its cancel entry)
+// forever.
+const defaultScanTimeout = 30 * time.Minute
+
 type Server struct {
-	engine        *engine.Engine
-	scans         map[string]*ScanResult
-	scansMutex    sync.RWMutex
-	webhookClient *http.Client
+	baseCtx      context.Context
+	store        ScanStore
+	scanCancels  map[string]context.CancelFunc
+	cancelsMutex sync.Mutex
+	webhooks     *WebhookDispatcher
+	broker       *Broker
+	auth         *authenticator
 }
 
+// NewServer builds a Server backed by the default in-memory ScanStore, an
+// unsigned webhook dispatcher, and no authentication. Use NewServerWithStore
+// to run against a persistent backend (SQLite, Postgres, Redis, ...) or to
+// configure a webhook signing secret, or NewServerWithAuth to additionally
+// require API keys, HMAC-signed requests, or OIDC bearer tokens.
 func NewServer() (*Server, error) {
-	e, err := engine.Start(context.Background())
+	return NewServerWithStore(NewMemoryStore(), "")
+}
+
+// NewServerWithStore builds a Server backed by the given ScanStore.
+// webhookSecret, if non-empty, is used to HMAC-sign outgoing webhook
+// payloads. The server accepts unauthenticated requests; use
+// NewServerWithAuth to require authentication.
+func NewServerWithStore(store ScanStore, webhookSecret string) (*Server, error) {
+	return NewServerWithAuth(store, webhookSecret, AuthConfig{})
+}
+
+// NewServerWithAuth builds a Server backed by the given ScanStore and
+// authConfig. A zero-value AuthConfig (no API keys, HMAC secrets, or OIDC
+// issuer) leaves the server unauthenticated, so existing callers of
+// NewServer/NewServerWithStore are unaffected; every request is then
+// attributed to defaultTenantID.
+func NewServerWithAuth(store ScanStore, webhookSecret string, authConfig AuthConfig) (*Server, error) {
+	ctx := context.Background()
+
+	auth, err := newAuthenticator(ctx, authConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to start engine: %w", err)
+		return nil, fmt.Errorf("failed to initialize authenticator: %w", err)
 	}
 
+	webhookClient := &http.Client{Timeout: 30 * time.Second}
+
 	return &Server{
-		engine:        e,
-		scans:         make(map[string]*ScanResult),
-		webhookClient: &http.Client{Timeout: 30 * time.Second},
+		baseCtx:     ctx,
+		store:       store,
+		scanCancels: make(map[string]context.CancelFunc),
+		webhooks:    NewWebhookDispatcher(webhookClient, webhookSecret, store),
+		broker:      NewBroker(),
+		auth:        auth,
 	}, nil
 }
 
@@ -91,24 +143,50 @@ func (s *Server) HandleScan(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.RepoURL == "" {
-		http.Error(w, "repo_url is required", http.StatusBadRequest)
+	sourceType := req.SourceType
+	if sourceType == "" {
+		sourceType = defaultSourceType
+	}
+	sourceConfig := req.SourceConfig
+	if len(sourceConfig) == 0 && sourceType == defaultSourceType {
+		sourceConfig = legacySourceConfig(req)
+	}
+
+	if _, _, err := validateSource(sourceType, sourceConfig); err != nil {
+		var cfgErr *SourceConfigError
+		if errors.As(err, &cfgErr) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error":   cfgErr.Message,
+				"details": cfgErr.Details,
+			})
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	req.SourceType = sourceType
+	req.SourceConfig = sourceConfig
+
 	scanID := uuid.New().String()
 	now := time.Now().UTC().Format(time.RFC3339)
 
+	principal, _ := principalFromContext(r.Context())
+
 	scanResult := &ScanResult{
 		ScanID:    scanID,
 		Status:    "pending",
 		RepoURL:   req.RepoURL,
+		TenantID:  principal.TenantID,
 		StartedAt: now,
 	}
 
-	s.scansMutex.Lock()
-	s.scans[scanID] = scanResult
-	s.scansMutex.Unlock()
+	if err := s.store.Create(r.Context(), scanResult); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to create scan: %v", err), http.StatusInternalServerError)
+		return
+	}
 
 	go s.performScan(scanID, req)
 
@@ -136,11 +214,20 @@ func (s *Server) HandleGetScan(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.scansMutex.RLock()
-	scanResult, exists := s.scans[scanID]
-	s.scansMutex.RUnlock()
+	scanResult, err := s.store.Get(r.Context(), scanID)
+	if errors.Is(err, ErrScanNotFound) {
+		http.Error(w, "Scan not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get scan: %v", err), http.StatusInternalServerError)
+		return
+	}
 
-	if !exists {
+	principal, _ := principalFromContext(r.Context())
+	if scanResult.TenantID != principal.TenantID {
+		// Report not-found rather than forbidden so a scan ID belonging to
+		// another tenant can't be distinguished from one that never existed.
 		http.Error(w, "Scan not found", http.StatusNotFound)
 		return
 	}
@@ -155,142 +242,379 @@ func (s *Server) HandleListScans(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.scansMutex.RLock()
-	scans := make([]*ScanResult, 0, len(s.scans))
-	for _, scan := range s.scans {
-		scans = append(scans, scan)
+	principal, _ := principalFromContext(r.Context())
+
+	query := r.URL.Query()
+	filter := ScanFilter{Status: query.Get("status"), TenantID: principal.TenantID}
+	pagination := Pagination{
+		Limit:  atoiOrZero(query.Get("limit")),
+		Offset: atoiOrZero(query.Get("offset")),
+	}
+
+	scans, total, err := s.store.List(r.Context(), filter, pagination)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list scans: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if scans == nil {
+		scans = []*ScanResult{}
 	}
-	s.scansMutex.RUnlock()
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"scans": scans,
-		"total": len(scans),
+		"scans":  scans,
+		"total":  total,
+		"limit":  pagination.Limit,
+		"offset": pagination.Offset,
 	})
 }
 
+// HandleCancelScan aborts an in-flight scan and marks it "cancelled". It is a
+// no-op if the scan has already finished, since the cancel function is
+// removed from scanCancels as soon as performScan returns; in that case it
+// reports the scan's actual terminal status rather than claiming it was just
+// cancelled. It is reachable both as DELETE /api/v1/scan and POST
+// /api/v1/scan/cancel.
+func (s *Server) HandleCancelScan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete && r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	scanID := r.URL.Query().Get("scan_id")
+	if scanID == "" {
+		http.Error(w, "scan_id parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	scanResult, err := s.store.Get(r.Context(), scanID)
+	if errors.Is(err, ErrScanNotFound) {
+		http.Error(w, "Scan not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get scan: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := principalFromContext(r.Context())
+	if scanResult.TenantID != principal.TenantID {
+		// Report not-found rather than forbidden so a scan ID belonging to
+		// another tenant can't be distinguished from one that never existed.
+		http.Error(w, "Scan not found", http.StatusNotFound)
+		return
+	}
+
+	s.cancelsMutex.Lock()
+	cancel, running := s.scanCancels[scanID]
+	s.cancelsMutex.Unlock()
+
+	status := scanResult.Status
+	if running {
+		status = "cancelled"
+		_ = s.store.UpdateStatus(r.Context(), scanID, ScanStatusUpdate{
+			Status:      status,
+			CompletedAt: time.Now().UTC().Format(time.RFC3339),
+		})
+		cancel()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"scan_id": scanID, "status": status})
+}
+
 func (s *Server) performScan(scanID string, req ScanRequest) {
-	s.scansMutex.Lock()
-	scanResult := s.scans[scanID]
-	scanResult.Status = "running"
-	s.scansMutex.Unlock()
+	timeout := defaultScanTimeout
+	if req.TimeoutSeconds > 0 {
+		timeout = time.Duration(req.TimeoutSeconds) * time.Second
+	}
 
-	ctx := context.Background()
-	
-	gitSource := &git.Source{}
-	conn, err := gitSource.Init(ctx, "trufflehog-api", 0, 0, req.Verify)
+	ctx, cancel := context.WithTimeout(s.baseCtx, timeout)
+	s.cancelsMutex.Lock()
+	s.scanCancels[scanID] = cancel
+	s.cancelsMutex.Unlock()
+
+	defer func() {
+		s.cancelsMutex.Lock()
+		delete(s.scanCancels, scanID)
+		s.cancelsMutex.Unlock()
+		cancel()
+	}()
+
+	_ = s.store.UpdateStatus(ctx, scanID, ScanStatusUpdate{Status: "running"})
+	s.emitWebhook(ctx, req.WebhookURL, scanID, "scan.started")
+
+	def, cfg, err := validateSource(req.SourceType, req.SourceConfig)
 	if err != nil {
-		s.updateScanError(scanID, fmt.Sprintf("Failed to initialize git source: %v", err))
-		s.sendWebhook(req.WebhookURL, scanID)
+		// Already validated synchronously in HandleScan; a failure here
+		// would mean the source_config changed shape between request and
+		// goroutine start, which can't happen for a single ScanRequest.
+		s.updateScanError(scanID, fmt.Sprintf("Failed to validate source config: %v", err))
+		s.emitWebhook(ctx, req.WebhookURL, scanID, "scan.failed")
+		return
+	}
+
+	conn, err := def.build(ctx, scanID, cfg)
+	if err != nil {
+		s.updateScanError(scanID, fmt.Sprintf("Failed to initialize %s source: %v", req.SourceType, err))
+		s.emitWebhook(ctx, req.WebhookURL, scanID, "scan.failed")
 		return
 	}
 
 	if err := conn.SetSourceUnit(ctx, sources.SourceUnit{
 		ID:   scanID,
-		Kind: "git",
+		Kind: req.SourceType,
 	}); err != nil {
 		s.updateScanError(scanID, fmt.Sprintf("Failed to set source unit: %v", err))
-		s.sendWebhook(req.WebhookURL, scanID)
+		s.emitWebhook(ctx, req.WebhookURL, scanID, "scan.failed")
+		return
+	}
+
+	// Each scan gets its own engine rather than sharing one across the
+	// process: a shared engine would mix concurrent scans' results on a
+	// single ResultsChan (misattributing them to whichever scanID happened
+	// to read them) and would never close that channel for an individual
+	// scan, so a normally-finishing scan would never see completion.
+	scanEngine, err := engine.Start(ctx)
+	if err != nil {
+		s.updateScanError(scanID, fmt.Sprintf("Failed to start scan engine: %v", err))
+		s.emitWebhook(ctx, req.WebhookURL, scanID, "scan.failed")
 		return
 	}
 
-	var secrets []SecretResult
-	resultsChan := make(chan detectors.Result, 100)
-	
+	chunksChan := make(chan *sources.Chunk, 100)
+	includeOnly := newDetectorFilter(detectorIncludeOnly(req, cfg))
+
+	// enumErr is written before chunksChan is closed and read only after the
+	// range loop over chunksChan below observes that close, so the channel close itself
+	// (a happens-before edge) makes the write visible without extra locking.
+	var enumErr error
 	go func() {
-		for result := range resultsChan {
-			secret := SecretResult{
-				DetectorType: result.DetectorType.String(),
-				DetectorName: result.DetectorName,
-				Verified:     result.Verified,
-				Redacted:     result.Redacted,
-				ExtraData:    result.ExtraData,
-			}
-			
-			if result.SourceMetadata != nil {
-				secret.SourceName = result.SourceMetadata.GetData().GetGit().GetRepository()
-				secret.SourceType = "git"
-			}
-			
-			secrets = append(secrets, secret)
+		defer close(chunksChan)
+		if err := conn.Chunks(ctx, chunksChan); err != nil {
+			enumErr = err
+			s.updateScanError(scanID, fmt.Sprintf("Failed to enumerate chunks: %v", err))
 		}
 	}()
 
-	// Note: This is a simplified version. In production, you'd integrate with the actual engine
-	// and properly handle the scanning process
-	close(resultsChan)
-
-	s.scansMutex.Lock()
-	scanResult.Status = "completed"
-	scanResult.CompletedAt = time.Now().UTC().Format(time.RFC3339)
-	scanResult.Secrets = secrets
-	scanResult.TotalSecrets = len(secrets)
-	
-	for _, secret := range secrets {
-		if secret.Verified {
-			scanResult.Verified++
-		} else {
-			scanResult.Unverified++
+	for chunk := range chunksChan {
+		select {
+		case <-ctx.Done():
+			s.finishCancelledScan(scanID, ctx.Err())
+			s.emitWebhook(ctx, req.WebhookURL, scanID, cancelledOrFailedEvent(ctx.Err()))
+			return
+		case scanEngine.ChunksChan() <- chunk:
 		}
 	}
-	s.scansMutex.Unlock()
 
-	s.sendWebhook(req.WebhookURL, scanID)
+	// All chunks for this scan have been enumerated and handed to its
+	// engine; Finish tells the engine to wind down once it drains them, so
+	// ResultsChan below closes when (and only when) this scan is done.
+	scanEngine.Finish(ctx)
+
+	secretIndex := 0
+	for {
+		select {
+		case <-ctx.Done():
+			s.finishCancelledScan(scanID, ctx.Err())
+			s.emitWebhook(ctx, req.WebhookURL, scanID, cancelledOrFailedEvent(ctx.Err()))
+			return
+		case result, ok := <-scanEngine.ResultsChan():
+			if !ok {
+				if enumErr != nil {
+					// Chunk enumeration already failed and updateScanError
+					// recorded it; don't let a clean engine shutdown
+					// overwrite that with a false "completed".
+					s.emitWebhook(ctx, req.WebhookURL, scanID, "scan.failed")
+					return
+				}
+				s.finishScan(scanID)
+				s.emitWebhook(ctx, req.WebhookURL, scanID, "scan.completed")
+				return
+			}
+			if !includeOnly.allows(result.DetectorType.String()) {
+				continue
+			}
+			secret := toSecretResult(req.SourceType, result)
+			_ = s.store.AppendSecret(ctx, scanID, secret)
+			s.broker.Publish(scanID, secretIndex, secret)
+			secretIndex++
+			s.emitWebhook(ctx, req.WebhookURL, scanID, "secret.found")
+		}
+	}
 }
 
-func (s *Server) updateScanError(scanID, errorMsg string) {
-	s.scansMutex.Lock()
-	defer s.scansMutex.Unlock()
-	
-	if scanResult, exists := s.scans[scanID]; exists {
-		scanResult.Status = "failed"
-		scanResult.Error = errorMsg
-		scanResult.CompletedAt = time.Now().UTC().Format(time.RFC3339)
+// cancelledOrFailedEvent maps a scan's terminal context error to the webhook
+// event it should report: a deadline means the scan's own timeout fired
+// (failed), anything else means an operator-initiated cancellation.
+func cancelledOrFailedEvent(ctxErr error) string {
+	if errors.Is(ctxErr, context.DeadlineExceeded) {
+		return "scan.failed"
 	}
+	return "scan.cancelled"
 }
 
-func (s *Server) sendWebhook(webhookURL, scanID string) {
+// emitWebhook looks up the current scan state and dispatches event to the
+// configured webhook URL (a no-op if webhookURL is empty). Delivery is
+// best-effort: failures are recorded in the scan's delivery history, not
+// returned to the caller.
+func (s *Server) emitWebhook(ctx context.Context, webhookURL, scanID, event string) {
 	if webhookURL == "" {
 		return
 	}
 
-	s.scansMutex.RLock()
-	scanResult := s.scans[scanID]
-	s.scansMutex.RUnlock()
+	// Read with s.baseCtx, not the scan-scoped ctx: on the cancel/timeout
+	// paths ctx is already Done by the time emitWebhook runs, and a
+	// context-aware store (sqlStore, redisStore) would fail this Get and
+	// silently drop the scan.cancelled/scan.failed event as a result.
+	scanResult, err := s.store.Get(s.baseCtx, scanID)
+	if err != nil {
+		return
+	}
+
+	go s.webhooks.Dispatch(s.baseCtx, webhookURL, scanID, event, *scanResult)
+}
 
-	payload := WebhookPayload{
-		Event:      "scan.completed",
-		ScanResult: *scanResult,
-		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+func toSecretResult(sourceType string, result detectors.Result) SecretResult {
+	secret := SecretResult{
+		DetectorType: result.DetectorType.String(),
+		DetectorName: result.DetectorName,
+		Verified:     result.Verified,
+		Redacted:     result.Redacted,
+		ExtraData:    result.ExtraData,
+		SourceType:   sourceType,
 	}
 
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
+	if result.SourceMetadata == nil {
+		return secret
+	}
+
+	data := result.SourceMetadata.GetData()
+	switch sourceType {
+	case "git":
+		gitMeta := data.GetGit()
+		secret.SourceName = gitMeta.GetRepository()
+		secret.File = gitMeta.GetFile()
+		secret.Commit = gitMeta.GetCommit()
+		secret.Line = gitMeta.GetLine()
+	case "github":
+		ghMeta := data.GetGithub()
+		secret.SourceName = ghMeta.GetRepository()
+		secret.File = ghMeta.GetFile()
+		secret.Commit = ghMeta.GetCommit()
+		secret.Line = ghMeta.GetLine()
+	case "gitlab":
+		glMeta := data.GetGitlab()
+		secret.SourceName = glMeta.GetRepository()
+		secret.File = glMeta.GetFile()
+		secret.Commit = glMeta.GetCommit()
+		secret.Line = glMeta.GetLine()
+	case "filesystem":
+		fsMeta := data.GetFilesystem()
+		secret.SourceName = fsMeta.GetFile()
+		secret.File = fsMeta.GetFile()
+	case "s3":
+		s3Meta := data.GetS3()
+		secret.SourceName = s3Meta.GetBucket()
+		secret.File = s3Meta.GetFile()
+	}
+
+	return secret
+}
+
+func (s *Server) finishScan(scanID string) {
+	_ = s.store.UpdateStatus(s.baseCtx, scanID, ScanStatusUpdate{
+		Status:      "completed",
+		CompletedAt: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+func (s *Server) finishCancelledScan(scanID string, ctxErr error) {
+	update := ScanStatusUpdate{CompletedAt: time.Now().UTC().Format(time.RFC3339)}
+	if errors.Is(ctxErr, context.DeadlineExceeded) {
+		update.Status = "failed"
+		update.Error = "scan exceeded its timeout"
+	} else {
+		update.Status = "cancelled"
+	}
+	_ = s.store.UpdateStatus(s.baseCtx, scanID, update)
+}
+
+func (s *Server) updateScanError(scanID, errorMsg string) {
+	_ = s.store.UpdateStatus(s.baseCtx, scanID, ScanStatusUpdate{
+		Status:      "failed",
+		Error:       errorMsg,
+		CompletedAt: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// HandleListWebhookDeliveries returns the delivery history for a scan's
+// webhook, most recent attempt last.
+func (s *Server) HandleListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	req, err := http.NewRequest(http.MethodPost, webhookURL, nil)
-	if err != nil {
+	scanID := r.URL.Query().Get("scan_id")
+	if scanID == "" {
+		http.Error(w, "scan_id parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	scanResult, err := s.store.Get(r.Context(), scanID)
+	if errors.Is(err, ErrScanNotFound) {
+		http.Error(w, "Scan not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get scan: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := principalFromContext(r.Context())
+	if scanResult.TenantID != principal.TenantID {
+		// Report not-found rather than forbidden so a scan ID belonging to
+		// another tenant can't be distinguished from one that never existed.
+		http.Error(w, "Scan not found", http.StatusNotFound)
 		return
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "TruffleHog-API/1.0")
-	req.Header.Set("X-TruffleHog-Event", "scan.completed")
-	
-	resp, err := s.webhookClient.Do(req)
+	deliveries, err := s.store.ListWebhookDeliveries(r.Context(), scanID)
 	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list webhook deliveries: %v", err), http.StatusInternalServerError)
 		return
 	}
-	defer resp.Body.Close()
+	if deliveries == nil {
+		deliveries = []WebhookDelivery{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"scan_id":    scanID,
+		"deliveries": deliveries,
+	})
+}
+
+func (s *Server) handleScanRoute(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.HandleScan(w, r)
+	case http.MethodDelete:
+		s.HandleCancelScan(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
 }
 
 func (s *Server) Start(addr string) error {
 	mux := http.NewServeMux()
-	
-	mux.HandleFunc("/api/v1/scan", s.HandleScan)
-	mux.HandleFunc("/api/v1/scan/status", s.HandleGetScan)
-	mux.HandleFunc("/api/v1/scans", s.HandleListScans)
+
+	mux.HandleFunc("/api/v1/scan", s.auth.middleware(s.handleScanRoute))
+	mux.HandleFunc("/api/v1/scan/cancel", s.auth.middleware(s.HandleCancelScan))
+	mux.HandleFunc("/api/v1/scan/status", s.auth.middleware(s.HandleGetScan))
+	mux.HandleFunc("/api/v1/scan/webhooks", s.auth.middleware(s.HandleListWebhookDeliveries))
+	mux.HandleFunc("/api/v1/scan/stream", s.auth.middleware(s.HandleStreamScan))
+	mux.HandleFunc("/api/v1/scan/ws", s.auth.middleware(s.HandleWebSocketScan))
+	mux.HandleFunc("/api/v1/scans", s.auth.middleware(s.HandleListScans))
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(map[string]string{"status": "healthy"})
@@ -0,0 +1,260 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/filesystem"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/git"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/github"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/gitlab"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/s3"
+)
+
+// defaultSourceType is assumed when a ScanRequest omits SourceType. If
+// SourceConfig is omitted too, the config is built from the legacy
+// top-level fields, preserving the pre-multi-source behavior of a bare
+// repo_url scan.
+const defaultSourceType = "git"
+
+// SourceConfigError wraps a source_config validation failure. HandleScan
+// surfaces it as a 400 with Details intact instead of letting the scan fail
+// asynchronously after being accepted.
+type SourceConfigError struct {
+	Message string
+	Details map[string]string
+}
+
+func (e *SourceConfigError) Error() string { return e.Message }
+
+// sourceDefinition pairs a source_config parser (cheap, synchronous, used
+// by HandleScan to validate up front) with the connection builder that
+// performScan runs once the scan is actually underway.
+type sourceDefinition struct {
+	parse func(raw json.RawMessage) (interface{}, error)
+	build func(ctx context.Context, scanID string, cfg interface{}) (sources.Connection, error)
+}
+
+var sourceDefinitions = map[string]sourceDefinition{
+	"git":        {parse: parseGitConfig, build: buildGitConnection},
+	"github":     {parse: parseGitHubConfig, build: buildGitHubConnection},
+	"gitlab":     {parse: parseGitLabConfig, build: buildGitLabConnection},
+	"filesystem": {parse: parseFilesystemConfig, build: buildFilesystemConnection},
+	"s3":         {parse: parseS3Config, build: buildS3Connection},
+}
+
+// validateSource resolves sourceType to its definition and parses raw
+// against it, returning a *SourceConfigError (never a bare error) on
+// failure so HandleScan can surface field-level details.
+func validateSource(sourceType string, raw json.RawMessage) (sourceDefinition, interface{}, error) {
+	def, ok := sourceDefinitions[sourceType]
+	if !ok {
+		return sourceDefinition{}, nil, &SourceConfigError{
+			Message: fmt.Sprintf("unsupported source_type %q", sourceType),
+			Details: map[string]string{"supported": "git, github, gitlab, filesystem, s3"},
+		}
+	}
+
+	cfg, err := def.parse(raw)
+	if err != nil {
+		var cfgErr *SourceConfigError
+		if errors.As(err, &cfgErr) {
+			return sourceDefinition{}, nil, cfgErr
+		}
+		return sourceDefinition{}, nil, &SourceConfigError{Message: fmt.Sprintf("invalid %s source_config: %v", sourceType, err)}
+	}
+
+	return def, cfg, nil
+}
+
+// --- git ---
+
+type gitConfig struct {
+	RepoURL     string   `json:"repo_url"`
+	IncludeOnly []string `json:"include_only"`
+	Verify      bool     `json:"verify"`
+}
+
+func parseGitConfig(raw json.RawMessage) (interface{}, error) {
+	var cfg gitConfig
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.RepoURL == "" {
+		return nil, &SourceConfigError{Message: "git source_config requires repo_url"}
+	}
+	return cfg, nil
+}
+
+func buildGitConnection(ctx context.Context, scanID string, cfgVal interface{}) (sources.Connection, error) {
+	cfg := cfgVal.(gitConfig)
+	source := &git.Source{}
+	return source.Init(ctx, "trufflehog-api", 0, 0, sources.SourceConfig{
+		RepoURL:     cfg.RepoURL,
+		IncludeOnly: cfg.IncludeOnly,
+		Verify:      cfg.Verify,
+	})
+}
+
+// --- github ---
+
+type githubConfig struct {
+	Org          string   `json:"org"`
+	Repos        []string `json:"repos"`
+	IncludeForks bool     `json:"include_forks"`
+	Token        string   `json:"token"`
+}
+
+func parseGitHubConfig(raw json.RawMessage) (interface{}, error) {
+	var cfg githubConfig
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Org == "" && len(cfg.Repos) == 0 {
+		return nil, &SourceConfigError{Message: "github source_config requires org or repos"}
+	}
+	if cfg.Token == "" {
+		return nil, &SourceConfigError{Message: "github source_config requires token"}
+	}
+	return cfg, nil
+}
+
+func buildGitHubConnection(ctx context.Context, scanID string, cfgVal interface{}) (sources.Connection, error) {
+	cfg := cfgVal.(githubConfig)
+	source := &github.Source{}
+	return source.Init(ctx, "trufflehog-api", 0, 0, github.Config{
+		Org:          cfg.Org,
+		Repos:        cfg.Repos,
+		IncludeForks: cfg.IncludeForks,
+		Token:        cfg.Token,
+	})
+}
+
+// --- gitlab ---
+
+type gitlabConfig struct {
+	Group           string   `json:"group"`
+	Projects        []string `json:"projects"`
+	IncludeArchived bool     `json:"include_archived"`
+	Token           string   `json:"token"`
+}
+
+func parseGitLabConfig(raw json.RawMessage) (interface{}, error) {
+	var cfg gitlabConfig
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Group == "" && len(cfg.Projects) == 0 {
+		return nil, &SourceConfigError{Message: "gitlab source_config requires group or projects"}
+	}
+	if cfg.Token == "" {
+		return nil, &SourceConfigError{Message: "gitlab source_config requires token"}
+	}
+	return cfg, nil
+}
+
+func buildGitLabConnection(ctx context.Context, scanID string, cfgVal interface{}) (sources.Connection, error) {
+	cfg := cfgVal.(gitlabConfig)
+	source := &gitlab.Source{}
+	return source.Init(ctx, "trufflehog-api", 0, 0, gitlab.Config{
+		Group:           cfg.Group,
+		Projects:        cfg.Projects,
+		IncludeArchived: cfg.IncludeArchived,
+		Token:           cfg.Token,
+	})
+}
+
+// --- filesystem ---
+
+type filesystemConfig struct {
+	Paths        []string `json:"paths"`
+	ExcludeGlobs []string `json:"exclude_globs"`
+}
+
+func parseFilesystemConfig(raw json.RawMessage) (interface{}, error) {
+	var cfg filesystemConfig
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	if len(cfg.Paths) == 0 {
+		return nil, &SourceConfigError{Message: "filesystem source_config requires paths"}
+	}
+	return cfg, nil
+}
+
+func buildFilesystemConnection(ctx context.Context, scanID string, cfgVal interface{}) (sources.Connection, error) {
+	cfg := cfgVal.(filesystemConfig)
+	source := &filesystem.Source{}
+	return source.Init(ctx, "trufflehog-api", 0, 0, filesystem.Config{
+		Paths:        cfg.Paths,
+		ExcludeGlobs: cfg.ExcludeGlobs,
+	})
+}
+
+// --- s3 ---
+
+type s3Config struct {
+	Buckets []string `json:"buckets"`
+	Roles   []string `json:"roles"`
+	Region  string   `json:"region"`
+}
+
+func parseS3Config(raw json.RawMessage) (interface{}, error) {
+	var cfg s3Config
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	if len(cfg.Buckets) == 0 {
+		return nil, &SourceConfigError{Message: "s3 source_config requires buckets"}
+	}
+	return cfg, nil
+}
+
+func buildS3Connection(ctx context.Context, scanID string, cfgVal interface{}) (sources.Connection, error) {
+	cfg := cfgVal.(s3Config)
+	source := &s3.Source{}
+	return source.Init(ctx, "trufflehog-api", 0, 0, s3.Config{
+		Buckets: cfg.Buckets,
+		Roles:   cfg.Roles,
+		Region:  cfg.Region,
+	})
+}
+
+// legacySourceConfig builds the source_config payload implied by a
+// ScanRequest's top-level RepoURL/IncludeOnly/Verify fields, so requests
+// written before source_type existed keep working unchanged.
+func legacySourceConfig(req ScanRequest) json.RawMessage {
+	cfg := gitConfig{RepoURL: req.RepoURL, IncludeOnly: req.IncludeOnly, Verify: req.Verify}
+	raw, _ := json.Marshal(cfg)
+	return raw
+}
+
+// detectorIncludeOnly resolves the detector allow-list for a scan. The
+// top-level ScanRequest.IncludeOnly field takes priority when set (this is
+// the legacy behavior, where it doubled as both the detector filter and the
+// git source's own include_only); otherwise, for a git scan submitted via
+// source_config, falls back to that config's include_only so restricting
+// detectors works through the new API too instead of silently filtering
+// nothing.
+func detectorIncludeOnly(req ScanRequest, cfg interface{}) []string {
+	if len(req.IncludeOnly) > 0 {
+		return req.IncludeOnly
+	}
+	if gitCfg, ok := cfg.(gitConfig); ok {
+		return gitCfg.IncludeOnly
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrScanNotFound is returned by ScanStore implementations when a lookup,
+// update, or delete targets a scan ID that doesn't exist.
+var ErrScanNotFound = errors.New("scan not found")
+
+// ScanFilter narrows a List call to scans matching the given criteria. A
+// zero-value ScanFilter matches everything.
+type ScanFilter struct {
+	Status string
+
+	// TenantID, when set, restricts List to scans created by that tenant.
+	// Handlers always set this from the request's Principal; it is never
+	// taken from client-supplied query parameters.
+	TenantID string
+}
+
+// Pagination bounds a List call. A zero-value Pagination means "no limit,
+// start from the beginning".
+type Pagination struct {
+	Limit  int
+	Offset int
+}
+
+// ScanStatusUpdate carries the fields performScan mutates as a scan
+// transitions between states.
+type ScanStatusUpdate struct {
+	Status      string
+	CompletedAt string
+	Error       string
+}
+
+// ScanStore persists ScanResults and is the single point through which
+// handlers and performScan read and write scan state. Implementations must
+// be safe for concurrent use.
+type ScanStore interface {
+	Create(ctx context.Context, scan *ScanResult) error
+	Get(ctx context.Context, scanID string) (*ScanResult, error)
+	List(ctx context.Context, filter ScanFilter, pagination Pagination) ([]*ScanResult, int, error)
+	UpdateStatus(ctx context.Context, scanID string, update ScanStatusUpdate) error
+	AppendSecret(ctx context.Context, scanID string, secret SecretResult) error
+	Delete(ctx context.Context, scanID string) error
+
+	AppendWebhookDelivery(ctx context.Context, scanID string, delivery WebhookDelivery) error
+	ListWebhookDeliveries(ctx context.Context, scanID string) ([]WebhookDelivery, error)
+}
+
+// memoryStore is the default ScanStore: an in-memory map that preserves the
+// server's original (pre-persistence) behavior. State does not survive a
+// restart and cannot be shared across replicas.
+type memoryStore struct {
+	mu       sync.RWMutex
+	scans    map[string]*ScanResult
+	webhooks map[string][]WebhookDelivery
+}
+
+// NewMemoryStore returns the default, non-persistent ScanStore.
+func NewMemoryStore() ScanStore {
+	return &memoryStore{
+		scans:    make(map[string]*ScanResult),
+		webhooks: make(map[string][]WebhookDelivery),
+	}
+}
+
+func (m *memoryStore) Create(_ context.Context, scan *ScanResult) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.scans[scan.ScanID] = scan
+	return nil
+}
+
+func (m *memoryStore) Get(_ context.Context, scanID string) (*ScanResult, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	scan, ok := m.scans[scanID]
+	if !ok {
+		return nil, ErrScanNotFound
+	}
+	// Return a copy so callers can't mutate store state without going
+	// through the interface.
+	cp := *scan
+	cp.Secrets = append([]SecretResult(nil), scan.Secrets...)
+	return &cp, nil
+}
+
+func (m *memoryStore) List(_ context.Context, filter ScanFilter, pagination Pagination) ([]*ScanResult, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	matched := make([]*ScanResult, 0, len(m.scans))
+	for _, scan := range m.scans {
+		if filter.Status != "" && scan.Status != filter.Status {
+			continue
+		}
+		if filter.TenantID != "" && scan.TenantID != filter.TenantID {
+			continue
+		}
+		cp := *scan
+		matched = append(matched, &cp)
+	}
+
+	total := len(matched)
+
+	if pagination.Offset > 0 {
+		if pagination.Offset >= len(matched) {
+			return []*ScanResult{}, total, nil
+		}
+		matched = matched[pagination.Offset:]
+	}
+	if pagination.Limit > 0 && pagination.Limit < len(matched) {
+		matched = matched[:pagination.Limit]
+	}
+
+	return matched, total, nil
+}
+
+func (m *memoryStore) UpdateStatus(_ context.Context, scanID string, update ScanStatusUpdate) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	scan, ok := m.scans[scanID]
+	if !ok {
+		return ErrScanNotFound
+	}
+
+	if update.Status != "" {
+		scan.Status = update.Status
+	}
+	if update.CompletedAt != "" {
+		scan.CompletedAt = update.CompletedAt
+	}
+	if update.Error != "" {
+		scan.Error = update.Error
+	}
+	return nil
+}
+
+func (m *memoryStore) AppendSecret(_ context.Context, scanID string, secret SecretResult) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	scan, ok := m.scans[scanID]
+	if !ok {
+		return ErrScanNotFound
+	}
+
+	scan.Secrets = append(scan.Secrets, secret)
+	scan.TotalSecrets++
+	if secret.Verified {
+		scan.Verified++
+	} else {
+		scan.Unverified++
+	}
+	return nil
+}
+
+func (m *memoryStore) Delete(_ context.Context, scanID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.scans[scanID]; !ok {
+		return ErrScanNotFound
+	}
+	delete(m.scans, scanID)
+	delete(m.webhooks, scanID)
+	return nil
+}
+
+func (m *memoryStore) AppendWebhookDelivery(_ context.Context, scanID string, delivery WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.webhooks[scanID] = append(m.webhooks[scanID], delivery)
+	return nil
+}
+
+func (m *memoryStore) ListWebhookDeliveries(_ context.Context, scanID string) ([]WebhookDelivery, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	deliveries := m.webhooks[scanID]
+	out := make([]WebhookDelivery, len(deliveries))
+	copy(out, deliveries)
+	return out, nil
+}
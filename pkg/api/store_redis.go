@@ -0,0 +1,249 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisStore is a ScanStore backed by Redis: each scan is a hash under
+// scan:<id> holding its scalar fields, and its secrets live as a JSON-encoded
+// list under scan:<id>:secrets. A scan:index set tracks known scan IDs so
+// List can page over them without a full KEYS scan.
+type redisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore returns a ScanStore backed by the given Redis client. The
+// caller owns the client's lifecycle.
+func NewRedisStore(client *redis.Client) ScanStore {
+	return &redisStore{client: client}
+}
+
+func scanKey(scanID string) string     { return "scan:" + scanID }
+func secretsKey(scanID string) string  { return "scan:" + scanID + ":secrets" }
+func webhooksKey(scanID string) string { return "scan:" + scanID + ":webhooks" }
+func scanIndexKey() string             { return "scan:index" }
+
+func (r *redisStore) Create(ctx context.Context, scan *ScanResult) error {
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, scanKey(scan.ScanID), scanToFields(scan))
+	pipe.SAdd(ctx, scanIndexKey(), scan.ScanID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to create scan %s: %w", scan.ScanID, err)
+	}
+	return nil
+}
+
+func (r *redisStore) Get(ctx context.Context, scanID string) (*ScanResult, error) {
+	fields, err := r.client.HGetAll(ctx, scanKey(scanID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scan %s: %w", scanID, err)
+	}
+	if len(fields) == 0 {
+		return nil, ErrScanNotFound
+	}
+
+	scan := fieldsToScan(scanID, fields)
+
+	secrets, err := r.secretsFor(ctx, scanID)
+	if err != nil {
+		return nil, err
+	}
+	scan.Secrets = secrets
+
+	return scan, nil
+}
+
+func (r *redisStore) List(ctx context.Context, filter ScanFilter, pagination Pagination) ([]*ScanResult, int, error) {
+	ids, err := r.client.SMembers(ctx, scanIndexKey()).Result()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list scan index: %w", err)
+	}
+
+	var matched []*ScanResult
+	for _, id := range ids {
+		fields, err := r.client.HGetAll(ctx, scanKey(id)).Result()
+		if err != nil || len(fields) == 0 {
+			continue
+		}
+		scan := fieldsToScan(id, fields)
+		if filter.Status != "" && scan.Status != filter.Status {
+			continue
+		}
+		if filter.TenantID != "" && scan.TenantID != filter.TenantID {
+			continue
+		}
+		matched = append(matched, scan)
+	}
+
+	total := len(matched)
+
+	if pagination.Offset > 0 {
+		if pagination.Offset >= len(matched) {
+			return []*ScanResult{}, total, nil
+		}
+		matched = matched[pagination.Offset:]
+	}
+	if pagination.Limit > 0 && pagination.Limit < len(matched) {
+		matched = matched[:pagination.Limit]
+	}
+
+	return matched, total, nil
+}
+
+func (r *redisStore) UpdateStatus(ctx context.Context, scanID string, update ScanStatusUpdate) error {
+	exists, err := r.client.Exists(ctx, scanKey(scanID)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to check scan %s: %w", scanID, err)
+	}
+	if exists == 0 {
+		return ErrScanNotFound
+	}
+
+	fields := map[string]interface{}{}
+	if update.Status != "" {
+		fields["status"] = update.Status
+	}
+	if update.CompletedAt != "" {
+		fields["completed_at"] = update.CompletedAt
+	}
+	if update.Error != "" {
+		fields["error"] = update.Error
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	if err := r.client.HSet(ctx, scanKey(scanID), fields).Err(); err != nil {
+		return fmt.Errorf("failed to update scan %s: %w", scanID, err)
+	}
+	return nil
+}
+
+func (r *redisStore) AppendSecret(ctx context.Context, scanID string, secret SecretResult) error {
+	exists, err := r.client.Exists(ctx, scanKey(scanID)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to check scan %s: %w", scanID, err)
+	}
+	if exists == 0 {
+		return ErrScanNotFound
+	}
+
+	payload, err := json.Marshal(secret)
+	if err != nil {
+		return fmt.Errorf("failed to marshal secret for scan %s: %w", scanID, err)
+	}
+
+	verifiedIncr, unverifiedIncr := 0, 1
+	if secret.Verified {
+		verifiedIncr, unverifiedIncr = 1, 0
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.RPush(ctx, secretsKey(scanID), payload)
+	pipe.HIncrBy(ctx, scanKey(scanID), "total_secrets", 1)
+	pipe.HIncrBy(ctx, scanKey(scanID), "verified", int64(verifiedIncr))
+	pipe.HIncrBy(ctx, scanKey(scanID), "unverified", int64(unverifiedIncr))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to append secret for scan %s: %w", scanID, err)
+	}
+	return nil
+}
+
+func (r *redisStore) Delete(ctx context.Context, scanID string) error {
+	n, err := r.client.Del(ctx, scanKey(scanID), secretsKey(scanID), webhooksKey(scanID)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to delete scan %s: %w", scanID, err)
+	}
+	r.client.SRem(ctx, scanIndexKey(), scanID)
+	if n == 0 {
+		return ErrScanNotFound
+	}
+	return nil
+}
+
+func (r *redisStore) AppendWebhookDelivery(ctx context.Context, scanID string, delivery WebhookDelivery) error {
+	payload, err := json.Marshal(delivery)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook delivery for scan %s: %w", scanID, err)
+	}
+	if err := r.client.RPush(ctx, webhooksKey(scanID), payload).Err(); err != nil {
+		return fmt.Errorf("failed to record webhook delivery for scan %s: %w", scanID, err)
+	}
+	return nil
+}
+
+func (r *redisStore) ListWebhookDeliveries(ctx context.Context, scanID string) ([]WebhookDelivery, error) {
+	raw, err := r.client.LRange(ctx, webhooksKey(scanID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries for scan %s: %w", scanID, err)
+	}
+
+	deliveries := make([]WebhookDelivery, 0, len(raw))
+	for _, payload := range raw {
+		var d WebhookDelivery
+		if err := json.Unmarshal([]byte(payload), &d); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal webhook delivery for scan %s: %w", scanID, err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, nil
+}
+
+func (r *redisStore) secretsFor(ctx context.Context, scanID string) ([]SecretResult, error) {
+	raw, err := r.client.LRange(ctx, secretsKey(scanID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secrets for scan %s: %w", scanID, err)
+	}
+
+	secrets := make([]SecretResult, 0, len(raw))
+	for _, payload := range raw {
+		var secret SecretResult
+		if err := json.Unmarshal([]byte(payload), &secret); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal secret for scan %s: %w", scanID, err)
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
+func scanToFields(scan *ScanResult) map[string]interface{} {
+	return map[string]interface{}{
+		"status":        scan.Status,
+		"repo_url":      scan.RepoURL,
+		"tenant_id":     scan.TenantID,
+		"started_at":    scan.StartedAt,
+		"completed_at":  scan.CompletedAt,
+		"total_secrets": scan.TotalSecrets,
+		"verified":      scan.Verified,
+		"unverified":    scan.Unverified,
+		"error":         scan.Error,
+	}
+}
+
+func atoiOrZero(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+func fieldsToScan(scanID string, fields map[string]string) *ScanResult {
+	return &ScanResult{
+		ScanID:       scanID,
+		Status:       fields["status"],
+		RepoURL:      fields["repo_url"],
+		TenantID:     fields["tenant_id"],
+		StartedAt:    fields["started_at"],
+		CompletedAt:  fields["completed_at"],
+		TotalSecrets: atoiOrZero(fields["total_secrets"]),
+		Verified:     atoiOrZero(fields["verified"]),
+		Unverified:   atoiOrZero(fields["unverified"]),
+		Error:        fields["error"],
+	}
+}
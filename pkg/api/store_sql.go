@@ -0,0 +1,348 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SQLDialect selects the DDL and placeholder style NewSQLStore uses, since
+// SQLite and Postgres agree on neither. There is no dialect detection from
+// *sql.DB (the driver name isn't reliably introspectable), so callers must
+// say which one they're opening.
+type SQLDialect string
+
+const (
+	DialectSQLite   SQLDialect = "sqlite"
+	DialectPostgres SQLDialect = "postgres"
+)
+
+// sqlSchema returns the scans/secrets/webhook_deliveries DDL for dialect.
+// SQLite's INTEGER PRIMARY KEY AUTOINCREMENT has no Postgres equivalent, so
+// the surrogate key column differs between the two; everything else is
+// ANSI-compatible and shared.
+func sqlSchema(dialect SQLDialect) string {
+	idColumn := "id INTEGER PRIMARY KEY AUTOINCREMENT"
+	if dialect == DialectPostgres {
+		idColumn = "id SERIAL PRIMARY KEY"
+	}
+
+	return fmt.Sprintf(`
+CREATE TABLE IF NOT EXISTS scans (
+	scan_id       TEXT PRIMARY KEY,
+	status        TEXT NOT NULL,
+	repo_url      TEXT NOT NULL,
+	tenant_id     TEXT NOT NULL DEFAULT '',
+	started_at    TEXT NOT NULL,
+	completed_at  TEXT,
+	total_secrets INTEGER NOT NULL DEFAULT 0,
+	verified      INTEGER NOT NULL DEFAULT 0,
+	unverified    INTEGER NOT NULL DEFAULT 0,
+	error         TEXT
+);
+
+CREATE TABLE IF NOT EXISTS secrets (
+	%s,
+	scan_id  TEXT NOT NULL REFERENCES scans(scan_id),
+	payload  TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+	%s,
+	scan_id     TEXT NOT NULL REFERENCES scans(scan_id),
+	delivery_id TEXT NOT NULL,
+	event       TEXT NOT NULL,
+	timestamp   TEXT NOT NULL,
+	status_code INTEGER,
+	error       TEXT,
+	attempt     INTEGER NOT NULL,
+	next_retry  TEXT
+);
+`, idColumn, idColumn)
+}
+
+// sqlStore is a ScanStore backed by any database/sql driver (SQLite,
+// Postgres, ...). Secrets are stored as JSON blobs rather than individual
+// columns since SecretResult's shape is still evolving alongside the
+// detector result format. Every query is written with `?` placeholders and
+// rebound through rebind for the store's dialect, since lib/pq/pgx reject
+// `?` and require `$1…$N`.
+type sqlStore struct {
+	db      *sql.DB
+	dialect SQLDialect
+}
+
+// NewSQLStore opens a ScanStore against db for the given dialect, creating
+// the scans/secrets/webhook_deliveries tables if needed. The caller owns
+// db's lifecycle (driver selection, DSN, connection pool tuning) and is
+// responsible for closing it.
+func NewSQLStore(ctx context.Context, db *sql.DB, dialect SQLDialect) (ScanStore, error) {
+	if _, err := db.ExecContext(ctx, sqlSchema(dialect)); err != nil {
+		return nil, fmt.Errorf("failed to migrate scan store schema: %w", err)
+	}
+	return &sqlStore{db: db, dialect: dialect}, nil
+}
+
+// rebind rewrites a query's `?` placeholders to `$1, $2, …` for Postgres,
+// and returns query unchanged for SQLite, which accepts `?` natively.
+func (s *sqlStore) rebind(query string) string {
+	if s.dialect != DialectPostgres {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func (s *sqlStore) Create(ctx context.Context, scan *ScanResult) error {
+	_, err := s.db.ExecContext(ctx, s.rebind(`
+		INSERT INTO scans (scan_id, status, repo_url, tenant_id, started_at, completed_at, total_secrets, verified, unverified, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		scan.ScanID, scan.Status, scan.RepoURL, scan.TenantID, scan.StartedAt, scan.CompletedAt,
+		scan.TotalSecrets, scan.Verified, scan.Unverified, scan.Error)
+	if err != nil {
+		return fmt.Errorf("failed to insert scan %s: %w", scan.ScanID, err)
+	}
+	return nil
+}
+
+func (s *sqlStore) Get(ctx context.Context, scanID string) (*ScanResult, error) {
+	row := s.db.QueryRowContext(ctx, s.rebind(`
+		SELECT scan_id, status, repo_url, tenant_id, started_at, completed_at, total_secrets, verified, unverified, error
+		FROM scans WHERE scan_id = ?`), scanID)
+
+	scan, err := scanScanResult(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrScanNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scan %s: %w", scanID, err)
+	}
+
+	secrets, err := s.secretsFor(ctx, scanID)
+	if err != nil {
+		return nil, err
+	}
+	scan.Secrets = secrets
+
+	return scan, nil
+}
+
+func (s *sqlStore) List(ctx context.Context, filter ScanFilter, pagination Pagination) ([]*ScanResult, int, error) {
+	query := `SELECT scan_id, status, repo_url, tenant_id, started_at, completed_at, total_secrets, verified, unverified, error FROM scans`
+	countQuery := `SELECT COUNT(*) FROM scans`
+
+	var conditions []string
+	var condArgs []interface{}
+	if filter.Status != "" {
+		conditions = append(conditions, `status = ?`)
+		condArgs = append(condArgs, filter.Status)
+	}
+	if filter.TenantID != "" {
+		conditions = append(conditions, `tenant_id = ?`)
+		condArgs = append(condArgs, filter.TenantID)
+	}
+	if len(conditions) > 0 {
+		where := ` WHERE ` + strings.Join(conditions, " AND ")
+		query += where
+		countQuery += where
+	}
+
+	args := append([]interface{}{}, condArgs...)
+	query += ` ORDER BY started_at DESC`
+
+	if pagination.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, pagination.Limit)
+	} else if pagination.Offset > 0 && s.dialect != DialectPostgres {
+		// SQLite only accepts OFFSET after a LIMIT; -1 means no limit.
+		query += ` LIMIT -1`
+	}
+	if pagination.Offset > 0 {
+		query += ` OFFSET ?`
+		args = append(args, pagination.Offset)
+	}
+
+	var total int
+	if err := s.db.QueryRowContext(ctx, s.rebind(countQuery), condArgs...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count scans: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.rebind(query), args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list scans: %w", err)
+	}
+	defer rows.Close()
+
+	var scans []*ScanResult
+	for rows.Next() {
+		scan, err := scanScanResult(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+		scans = append(scans, scan)
+	}
+	return scans, total, rows.Err()
+}
+
+func (s *sqlStore) UpdateStatus(ctx context.Context, scanID string, update ScanStatusUpdate) error {
+	res, err := s.db.ExecContext(ctx, s.rebind(`
+		UPDATE scans SET
+			status = COALESCE(NULLIF(?, ''), status),
+			completed_at = COALESCE(NULLIF(?, ''), completed_at),
+			error = COALESCE(NULLIF(?, ''), error)
+		WHERE scan_id = ?`),
+		update.Status, update.CompletedAt, update.Error, scanID)
+	if err != nil {
+		return fmt.Errorf("failed to update scan %s: %w", scanID, err)
+	}
+	return checkRowsAffected(res, scanID)
+}
+
+func (s *sqlStore) AppendSecret(ctx context.Context, scanID string, secret SecretResult) error {
+	payload, err := json.Marshal(secret)
+	if err != nil {
+		return fmt.Errorf("failed to marshal secret for scan %s: %w", scanID, err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, s.rebind(`INSERT INTO secrets (scan_id, payload) VALUES (?, ?)`), scanID, payload); err != nil {
+		return fmt.Errorf("failed to insert secret for scan %s: %w", scanID, err)
+	}
+
+	verifiedDelta, unverifiedDelta := 0, 1
+	if secret.Verified {
+		verifiedDelta, unverifiedDelta = 1, 0
+	}
+
+	res, err := tx.ExecContext(ctx, s.rebind(`
+		UPDATE scans SET total_secrets = total_secrets + 1, verified = verified + ?, unverified = unverified + ?
+		WHERE scan_id = ?`), verifiedDelta, unverifiedDelta, scanID)
+	if err != nil {
+		return fmt.Errorf("failed to update scan counters for %s: %w", scanID, err)
+	}
+	if err := checkRowsAffected(res, scanID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqlStore) Delete(ctx context.Context, scanID string) error {
+	if _, err := s.db.ExecContext(ctx, s.rebind(`DELETE FROM secrets WHERE scan_id = ?`), scanID); err != nil {
+		return fmt.Errorf("failed to delete secrets for scan %s: %w", scanID, err)
+	}
+	res, err := s.db.ExecContext(ctx, s.rebind(`DELETE FROM scans WHERE scan_id = ?`), scanID)
+	if err != nil {
+		return fmt.Errorf("failed to delete scan %s: %w", scanID, err)
+	}
+	return checkRowsAffected(res, scanID)
+}
+
+func (s *sqlStore) AppendWebhookDelivery(ctx context.Context, scanID string, delivery WebhookDelivery) error {
+	_, err := s.db.ExecContext(ctx, s.rebind(`
+		INSERT INTO webhook_deliveries (scan_id, delivery_id, event, timestamp, status_code, error, attempt, next_retry)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`),
+		scanID, delivery.DeliveryID, delivery.Event, delivery.Timestamp,
+		delivery.StatusCode, delivery.Error, delivery.Attempt, delivery.NextRetry)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery for scan %s: %w", scanID, err)
+	}
+	return nil
+}
+
+func (s *sqlStore) ListWebhookDeliveries(ctx context.Context, scanID string) ([]WebhookDelivery, error) {
+	rows, err := s.db.QueryContext(ctx, s.rebind(`
+		SELECT delivery_id, event, timestamp, status_code, error, attempt, next_retry
+		FROM webhook_deliveries WHERE scan_id = ? ORDER BY id ASC`), scanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries for scan %s: %w", scanID, err)
+	}
+	defer rows.Close()
+
+	var deliveries []WebhookDelivery
+	for rows.Next() {
+		var d WebhookDelivery
+		var statusCode sql.NullInt64
+		var errMsg, nextRetry sql.NullString
+		if err := rows.Scan(&d.DeliveryID, &d.Event, &d.Timestamp, &statusCode, &errMsg, &d.Attempt, &nextRetry); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery row: %w", err)
+		}
+		d.StatusCode = int(statusCode.Int64)
+		d.Error = errMsg.String
+		d.NextRetry = nextRetry.String
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+func (s *sqlStore) secretsFor(ctx context.Context, scanID string) ([]SecretResult, error) {
+	rows, err := s.db.QueryContext(ctx, s.rebind(`SELECT payload FROM secrets WHERE scan_id = ? ORDER BY id ASC`), scanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query secrets for scan %s: %w", scanID, err)
+	}
+	defer rows.Close()
+
+	var secrets []SecretResult
+	for rows.Next() {
+		var payload []byte
+		if err := rows.Scan(&payload); err != nil {
+			return nil, fmt.Errorf("failed to scan secret row: %w", err)
+		}
+		var secret SecretResult
+		if err := json.Unmarshal(payload, &secret); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal secret for scan %s: %w", scanID, err)
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, rows.Err()
+}
+
+// rowScanner abstracts over *sql.Row and *sql.Rows so scanScanResult can
+// serve both Get (single row) and List (row iteration).
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanScanResult(row rowScanner) (*ScanResult, error) {
+	var scan ScanResult
+	var completedAt, errMsg sql.NullString
+	if err := row.Scan(
+		&scan.ScanID, &scan.Status, &scan.RepoURL, &scan.TenantID, &scan.StartedAt, &completedAt,
+		&scan.TotalSecrets, &scan.Verified, &scan.Unverified, &errMsg,
+	); err != nil {
+		return nil, err
+	}
+	scan.CompletedAt = completedAt.String
+	scan.Error = errMsg.String
+	return &scan, nil
+}
+
+func checkRowsAffected(res sql.Result, scanID string) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected for scan %s: %w", scanID, err)
+	}
+	if n == 0 {
+		return ErrScanNotFound
+	}
+	return nil
+}
@@ -0,0 +1,312 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// sseKeepAlive is how often the SSE handler writes a comment frame to keep
+// idle connections (and the proxies in front of them) from timing out.
+const sseKeepAlive = 15 * time.Second
+
+// secretEvent pairs a published secret with its 0-based index in the scan's
+// persisted Secrets slice, so a reconnecting client can dedupe the replay it
+// read from the store (up to since_index) against whatever the broker
+// delivers live, with no gap and no double-delivery between the two.
+type secretEvent struct {
+	Index  int
+	Secret SecretResult
+}
+
+// Broker fans out detector results to whatever clients are currently
+// streaming a given scan. Subscribers are plain channels; the broker never
+// blocks a publisher on a slow subscriber beyond the channel's buffer.
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan secretEvent]struct{}
+}
+
+// NewBroker returns an empty Broker.
+func NewBroker() *Broker {
+	return &Broker{subscribers: make(map[string]map[chan secretEvent]struct{})}
+}
+
+// Subscribe registers a new subscriber for scanID and returns its channel.
+// Callers must Unsubscribe when done to avoid leaking the channel.
+func (b *Broker) Subscribe(scanID string) chan secretEvent {
+	ch := make(chan secretEvent, 32)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subscribers[scanID] == nil {
+		b.subscribers[scanID] = make(map[chan secretEvent]struct{})
+	}
+	b.subscribers[scanID][ch] = struct{}{}
+	return ch
+}
+
+// Unsubscribe removes ch from scanID's subscriber set and closes it.
+func (b *Broker) Unsubscribe(scanID string, ch chan secretEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if subs, ok := b.subscribers[scanID]; ok {
+		delete(subs, ch)
+		if len(subs) == 0 {
+			delete(b.subscribers, scanID)
+		}
+	}
+	close(ch)
+}
+
+// Publish delivers secret, tagged with its index in the scan's Secrets
+// slice, to every current subscriber of scanID. A subscriber whose buffer
+// is full is skipped rather than blocking the scan's results loop.
+func (b *Broker) Publish(scanID string, index int, secret SecretResult) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	ev := secretEvent{Index: index, Secret: secret}
+	for ch := range b.subscribers[scanID] {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+// HandleStreamScan serves scan results as they're detected over SSE,
+// replaying anything the client already missed via since_index.
+func (s *Server) HandleStreamScan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	scanID := r.URL.Query().Get("scan_id")
+	if scanID == "" {
+		http.Error(w, "scan_id parameter is required", http.StatusBadRequest)
+		return
+	}
+	sinceIndex := atoiOrZero(r.URL.Query().Get("since_index"))
+
+	// Subscribe before reading the store snapshot below, so a secret
+	// appended+published in the gap between the snapshot and subscribing
+	// is still delivered live instead of falling through a blind spot.
+	ch := s.broker.Subscribe(scanID)
+	defer s.broker.Unsubscribe(scanID, ch)
+
+	scan, err := s.store.Get(r.Context(), scanID)
+	if errors.Is(err, ErrScanNotFound) {
+		http.Error(w, "Scan not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get scan: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := principalFromContext(r.Context())
+	if scan.TenantID != principal.TenantID {
+		// Report not-found rather than forbidden so a scan ID belonging to
+		// another tenant can't be distinguished from one that never existed.
+		http.Error(w, "Scan not found", http.StatusNotFound)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	writeSSE := func(secret SecretResult) bool {
+		data, err := json.Marshal(secret)
+		if err != nil {
+			return true
+		}
+		if _, err := fmt.Fprintf(w, "event: secret\ndata: %s\n\n", data); err != nil {
+			return false
+		}
+		flusher.Flush()
+		return true
+	}
+
+	nextIndex := sinceIndex
+	if nextIndex < 0 {
+		nextIndex = 0
+	}
+	for _, secret := range replaySecrets(scan, sinceIndex) {
+		if !writeSSE(secret) {
+			return
+		}
+		nextIndex++
+	}
+
+	if isTerminalStatus(scan.Status) {
+		return
+	}
+
+	ticker := time.NewTicker(sseKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev := <-ch:
+			if ev.Index < nextIndex {
+				continue // already delivered from the persisted snapshot above
+			}
+			if !writeSSE(ev.Secret) {
+				return
+			}
+			nextIndex = ev.Index + 1
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+
+			scan, err := s.store.Get(r.Context(), scanID)
+			if err == nil && isTerminalStatus(scan.Status) {
+				return
+			}
+		}
+	}
+}
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// Scan streaming is consumed by the same clients that initiate scans via
+	// the REST API, not arbitrary browser origins, so the default same-origin
+	// check is intentionally skipped here.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// HandleWebSocketScan serves scan results as they're detected over a
+// WebSocket connection, replaying anything the client already missed via
+// since_index.
+func (s *Server) HandleWebSocketScan(w http.ResponseWriter, r *http.Request) {
+	scanID := r.URL.Query().Get("scan_id")
+	if scanID == "" {
+		http.Error(w, "scan_id parameter is required", http.StatusBadRequest)
+		return
+	}
+	sinceIndex := atoiOrZero(r.URL.Query().Get("since_index"))
+
+	// Subscribe before reading the store snapshot below, so a secret
+	// appended+published in the gap between the snapshot and subscribing
+	// is still delivered live instead of falling through a blind spot.
+	ch := s.broker.Subscribe(scanID)
+	defer s.broker.Unsubscribe(scanID, ch)
+
+	scan, err := s.store.Get(r.Context(), scanID)
+	if errors.Is(err, ErrScanNotFound) {
+		http.Error(w, "Scan not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get scan: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	principal, _ := principalFromContext(r.Context())
+	if scan.TenantID != principal.TenantID {
+		// Report not-found rather than forbidden so a scan ID belonging to
+		// another tenant can't be distinguished from one that never existed.
+		http.Error(w, "Scan not found", http.StatusNotFound)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(sseKeepAlive * 2))
+	})
+	go discardIncoming(conn)
+
+	nextIndex := sinceIndex
+	if nextIndex < 0 {
+		nextIndex = 0
+	}
+	for _, secret := range replaySecrets(scan, sinceIndex) {
+		if conn.WriteJSON(secret) != nil {
+			return
+		}
+		nextIndex++
+	}
+
+	if isTerminalStatus(scan.Status) {
+		return
+	}
+
+	ticker := time.NewTicker(sseKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return
+			}
+			if ev.Index < nextIndex {
+				continue // already delivered from the persisted snapshot above
+			}
+			if conn.WriteJSON(ev.Secret) != nil {
+				return
+			}
+			nextIndex = ev.Index + 1
+		case <-ticker.C:
+			if conn.WriteMessage(websocket.PingMessage, nil) != nil {
+				return
+			}
+
+			scan, err := s.store.Get(r.Context(), scanID)
+			if err == nil && isTerminalStatus(scan.Status) {
+				return
+			}
+		}
+	}
+}
+
+// discardIncoming drains client frames (pings, close) so gorilla's read
+// pump keeps servicing SetPongHandler; the scan stream itself is one-way.
+func discardIncoming(conn *websocket.Conn) {
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func replaySecrets(scan *ScanResult, sinceIndex int) []SecretResult {
+	if sinceIndex < 0 || sinceIndex >= len(scan.Secrets) {
+		return nil
+	}
+	return scan.Secrets[sinceIndex:]
+}
+
+func isTerminalStatus(status string) bool {
+	switch status {
+	case "completed", "failed", "cancelled":
+		return true
+	default:
+		return false
+	}
+}
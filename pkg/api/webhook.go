@@ -0,0 +1,156 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookDelivery records a single attempt to deliver a webhook event, so
+// clients can audit what was sent and when a retry is still pending.
+type WebhookDelivery struct {
+	DeliveryID string `json:"delivery_id"`
+	Event      string `json:"event"`
+	Timestamp  string `json:"timestamp"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Attempt    int    `json:"attempt"`
+	NextRetry  string `json:"next_retry,omitempty"`
+}
+
+// webhookMaxAttempts bounds retries for 5xx/429 responses and network
+// errors: 1s, 2s, 4s, 8s, then give up.
+const webhookMaxAttempts = 5
+
+// WebhookDispatcher signs and delivers webhook payloads, retrying transient
+// failures with exponential backoff and recording every attempt to a
+// ScanStore so delivery history can be queried later.
+type WebhookDispatcher struct {
+	client *http.Client
+	secret string
+	store  ScanStore
+}
+
+// NewWebhookDispatcher builds a dispatcher that signs payloads with secret
+// (empty disables signing) and persists delivery attempts to store.
+func NewWebhookDispatcher(client *http.Client, secret string, store ScanStore) *WebhookDispatcher {
+	return &WebhookDispatcher{client: client, secret: secret, store: store}
+}
+
+// Dispatch sends event for scanResult to url, retrying on network errors and
+// 5xx/429 responses. It never returns an error to the caller — all outcomes,
+// including exhausted retries, are recorded as WebhookDeliveries instead.
+func (d *WebhookDispatcher) Dispatch(ctx context.Context, url, scanID, event string, scanResult ScanResult) {
+	if url == "" {
+		return
+	}
+
+	payload := WebhookPayload{
+		Event:      event,
+		ScanResult: scanResult,
+		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+
+	deliveryID := uuid.New().String()
+	signature := d.sign(body)
+
+	var lastStatus int
+	var lastErr error
+
+	for attempt := 1; attempt <= webhookMaxAttempts; attempt++ {
+		lastStatus, lastErr = d.attempt(ctx, url, event, deliveryID, signature, body)
+
+		delivery := WebhookDelivery{
+			DeliveryID: deliveryID,
+			Event:      event,
+			Timestamp:  time.Now().UTC().Format(time.RFC3339),
+			StatusCode: lastStatus,
+			Attempt:    attempt,
+		}
+		if lastErr != nil {
+			delivery.Error = lastErr.Error()
+		}
+
+		retryable := lastErr != nil || lastStatus >= 500 || lastStatus == http.StatusTooManyRequests
+		if retryable && attempt < webhookMaxAttempts {
+			wait := backoff(attempt)
+			delivery.NextRetry = time.Now().UTC().Add(wait).Format(time.RFC3339)
+			_ = d.store.AppendWebhookDelivery(ctx, scanID, delivery)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+			continue
+		}
+
+		_ = d.store.AppendWebhookDelivery(ctx, scanID, delivery)
+		return
+	}
+}
+
+func (d *WebhookDispatcher) attempt(ctx context.Context, url, event, deliveryID, signature string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	req.Header.Set("User-Agent", "TruffleHog-API/1.0")
+	req.Header.Set("X-TruffleHog-Event", event)
+	req.Header.Set("X-TruffleHog-Delivery", deliveryID)
+	if signature != "" {
+		req.Header.Set("X-TruffleHog-Signature", "sha256="+signature)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if secs, err := strconv.Atoi(retryAfter); err == nil && secs > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Duration(secs) * time.Second):
+			}
+		}
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (d *WebhookDispatcher) sign(body []byte) string {
+	if d.secret == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(d.secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff returns the exponential delay for the given 1-indexed attempt
+// (1s, 2s, 4s, 8s, ...) plus up to 250ms of jitter, so a thundering herd of
+// failing webhooks doesn't retry in lockstep.
+func backoff(attempt int) time.Duration {
+	base := time.Duration(1<<uint(attempt-1)) * time.Second
+	jitter := time.Duration(rand.Intn(250)) * time.Millisecond
+	return base + jitter
+}